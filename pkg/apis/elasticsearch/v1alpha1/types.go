@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElasticsearchNodeRole identifies the role an Elasticsearch node plays
+// within the cluster.
+type ElasticsearchNodeRole string
+
+const (
+	ElasticsearchRoleClient ElasticsearchNodeRole = "client"
+	ElasticsearchRoleData   ElasticsearchNodeRole = "data"
+	ElasticsearchRoleMaster ElasticsearchNodeRole = "master"
+)
+
+// PodStateType buckets a pod by observed readiness.
+type PodStateType string
+
+const (
+	PodStateTypeReady    PodStateType = "ready"
+	PodStateTypeNotReady PodStateType = "notReady"
+	PodStateTypeFailed   PodStateType = "failed"
+	// PodStateTypeUpdating buckets pods whose owning workload has not yet
+	// finished rolling out, distinguishing a rolling restart in progress
+	// from a genuine failure.
+	PodStateTypeUpdating PodStateType = "updating"
+)
+
+// PodStateMap maps a PodStateType to the names of the pods in that state.
+type PodStateMap map[PodStateType][]string
+
+// ElasticsearchNodeStatus describes the workload backing a single
+// Elasticsearch node managed by this CR.
+type ElasticsearchNodeStatus struct {
+	DeploymentName  string                  `json:"deploymentName,omitempty"`
+	ReplicaSetName  string                  `json:"replicaSetName,omitempty"`
+	StatefulSetName string                  `json:"statefulSetName,omitempty"`
+	PodName         string                  `json:"podName,omitempty"`
+	Status          string                  `json:"status,omitempty"`
+	Roles           []ElasticsearchNodeRole `json:"roles,omitempty"`
+}
+
+// ElasticsearchStatus is the observed state of an Elasticsearch cluster.
+type ElasticsearchStatus struct {
+	Nodes         []ElasticsearchNodeStatus             `json:"nodes,omitempty"`
+	ClusterHealth string                                `json:"clusterHealth,omitempty"`
+	Pods          map[ElasticsearchNodeRole]PodStateMap `json:"pods,omitempty"`
+
+	// NumberOfNodes is the number of nodes currently joined to the cluster.
+	NumberOfNodes int `json:"numberOfNodes,omitempty"`
+	// ActiveShards is the number of active shards cluster-wide.
+	ActiveShards int `json:"activeShards,omitempty"`
+	// RelocatingShards is the number of shards currently relocating.
+	RelocatingShards int `json:"relocatingShards,omitempty"`
+	// InitializingShards is the number of shards currently initializing.
+	InitializingShards int `json:"initializingShards,omitempty"`
+	// UnassignedShards is the number of shards not currently assigned to a node.
+	UnassignedShards int `json:"unassignedShards,omitempty"`
+	// PendingTasks is the number of cluster-level changes not yet executed.
+	PendingTasks int `json:"pendingTasks,omitempty"`
+	// ActiveShardsPercent is the percentage of active shards cluster-wide.
+	ActiveShardsPercent float64 `json:"activeShardsPercent,omitempty"`
+
+	// Conditions is the set of observed conditions for the cluster,
+	// modeled on the standard Kubernetes condition pattern so that e.g.
+	// `kubectl wait --for=condition=ClusterHealthy` works out of the box.
+	Conditions []ElasticsearchCondition `json:"conditions,omitempty"`
+}
+
+// ElasticsearchConditionType is a type of condition reported on an
+// Elasticsearch cluster's status.
+type ElasticsearchConditionType string
+
+const (
+	ClusterHealthy    ElasticsearchConditionType = "ClusterHealthy"
+	AllShardsAssigned ElasticsearchConditionType = "AllShardsAssigned"
+	Upgrading         ElasticsearchConditionType = "Upgrading"
+	Degraded          ElasticsearchConditionType = "Degraded"
+	QuorumLost        ElasticsearchConditionType = "QuorumLost"
+)
+
+// ElasticsearchCondition is a single observed condition of an Elasticsearch
+// cluster.
+type ElasticsearchCondition struct {
+	Type               ElasticsearchConditionType `json:"type"`
+	Status             v1.ConditionStatus         `json:"status"`
+	Reason             string                     `json:"reason,omitempty"`
+	Message            string                     `json:"message,omitempty"`
+	LastTransitionTime metav1.Time                `json:"lastTransitionTime,omitempty"`
+	LastHeartbeatTime  metav1.Time                `json:"lastHeartbeatTime,omitempty"`
+}
+
+// ElasticsearchSpec is the desired state of an Elasticsearch cluster.
+type ElasticsearchSpec struct {
+}
+
+// Elasticsearch is the schema for the elasticsearches API.
+type Elasticsearch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticsearchSpec   `json:"spec"`
+	Status ElasticsearchStatus `json:"status,omitempty"`
+}
+
+// ElasticsearchList contains a list of Elasticsearch clusters.
+type ElasticsearchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Elasticsearch `json:"items"`
+}
@@ -0,0 +1,111 @@
+// Package elasticsearch provides a thin HTTP client for talking directly to
+// an Elasticsearch cluster managed by the operator, using the admin
+// certificate the operator already has mounted.
+package elasticsearch
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	adminCertPath = "/etc/elasticsearch/secret/admin-cert"
+	adminKeyPath  = "/etc/elasticsearch/secret/admin-key"
+	adminCAPath   = "/etc/elasticsearch/secret/admin-ca"
+
+	requestTimeout = 10 * time.Second
+)
+
+// Client talks to the Elasticsearch REST API for a single cluster over its
+// in-cluster service.
+type Client struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewClient builds a Client for the Elasticsearch service backing
+// clusterName in namespace, authenticating with the operator's mounted
+// admin certificate.
+func NewClient(clusterName, namespace string) (*Client, error) {
+	tlsConfig, err := adminTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		endpoint: fmt.Sprintf("https://%s.%s.svc:9200", clusterName, namespace),
+		httpClient: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+func adminTLSConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(adminCertPath, adminKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load elasticsearch admin cert/key: %v", err)
+	}
+
+	ca, err := ioutil.ReadFile(adminCAPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load elasticsearch admin ca: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("could not parse elasticsearch admin ca %q", adminCAPath)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+	}, nil
+}
+
+// ClusterHealth is the strongly-typed response from the Elasticsearch
+// _cluster/health API.
+type ClusterHealth struct {
+	ClusterName                 string  `json:"cluster_name"`
+	Status                      string  `json:"status"`
+	TimedOut                    bool    `json:"timed_out"`
+	NumberOfNodes               int     `json:"number_of_nodes"`
+	NumberOfDataNodes           int     `json:"number_of_data_nodes"`
+	ActiveShards                int     `json:"active_shards"`
+	RelocatingShards            int     `json:"relocating_shards"`
+	InitializingShards          int     `json:"initializing_shards"`
+	UnassignedShards            int     `json:"unassigned_shards"`
+	NumberOfPendingTasks        int     `json:"number_of_pending_tasks"`
+	ActiveShardsPercentAsNumber float64 `json:"active_shards_percent_as_number"`
+}
+
+// ClusterHealth fetches /_cluster/health from the cluster.
+func (c *Client) ClusterHealth() (*ClusterHealth, error) {
+	health := &ClusterHealth{}
+	if err := c.get("/_cluster/health", health); err != nil {
+		return nil, err
+	}
+	return health, nil
+}
+
+func (c *Client) get(path string, out interface{}) error {
+	resp, err := c.httpClient.Get(c.endpoint + path)
+	if err != nil {
+		return fmt.Errorf("could not reach elasticsearch at %v: %v", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("elasticsearch returned status %v for %v", resp.StatusCode, path)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("could not decode elasticsearch response from %v: %v", path, err)
+	}
+	return nil
+}
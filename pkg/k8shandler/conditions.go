@@ -0,0 +1,111 @@
+package k8shandler
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/openshift/elasticsearch-operator/pkg/apis/elasticsearch/v1alpha1"
+)
+
+// computeConditions derives dpl.Status.Conditions from the cluster health
+// and pod state already populated on dpl.Status, preserving
+// LastTransitionTime for any condition whose Status hasn't flipped since
+// the last reconcile.
+func computeConditions(dpl *v1alpha1.Elasticsearch) {
+	now := metav1.Now()
+	desired := []v1alpha1.ElasticsearchCondition{
+		healthyCondition(dpl),
+		shardsAssignedCondition(dpl),
+		upgradingCondition(dpl),
+		degradedCondition(dpl),
+		quorumCondition(dpl),
+	}
+
+	dpl.Status.Conditions = mergeConditions(dpl.Status.Conditions, desired, now)
+}
+
+func mergeConditions(existing, desired []v1alpha1.ElasticsearchCondition, now metav1.Time) []v1alpha1.ElasticsearchCondition {
+	merged := make([]v1alpha1.ElasticsearchCondition, 0, len(desired))
+	for _, cond := range desired {
+		cond.LastHeartbeatTime = now
+		cond.LastTransitionTime = now
+
+		for _, prev := range existing {
+			if prev.Type == cond.Type && prev.Status == cond.Status {
+				cond.LastTransitionTime = prev.LastTransitionTime
+				break
+			}
+		}
+		merged = append(merged, cond)
+	}
+	return merged
+}
+
+func healthyCondition(dpl *v1alpha1.Elasticsearch) v1alpha1.ElasticsearchCondition {
+	status := v1.ConditionFalse
+	reason, message := "ClusterHealthDegraded", fmt.Sprintf("cluster health is %q", dpl.Status.ClusterHealth)
+	if dpl.Status.ClusterHealth == "green" {
+		status = v1.ConditionTrue
+		reason, message = "ClusterHealthGreen", "cluster health is green"
+	}
+
+	return v1alpha1.ElasticsearchCondition{Type: v1alpha1.ClusterHealthy, Status: status, Reason: reason, Message: message}
+}
+
+func shardsAssignedCondition(dpl *v1alpha1.Elasticsearch) v1alpha1.ElasticsearchCondition {
+	status := v1.ConditionTrue
+	reason, message := "AllShardsAssigned", "all shards are assigned"
+	if dpl.Status.UnassignedShards > 0 {
+		status = v1.ConditionFalse
+		reason, message = "ShardsUnassigned", fmt.Sprintf("%v shards are unassigned", dpl.Status.UnassignedShards)
+	}
+
+	return v1alpha1.ElasticsearchCondition{Type: v1alpha1.AllShardsAssigned, Status: status, Reason: reason, Message: message}
+}
+
+func upgradingCondition(dpl *v1alpha1.Elasticsearch) v1alpha1.ElasticsearchCondition {
+	status := v1.ConditionFalse
+	reason, message := "NoUpdateInProgress", "no node is rolling out an update"
+	if countPodState(dpl, v1alpha1.PodStateTypeUpdating) > 0 {
+		status = v1.ConditionTrue
+		reason, message = "RollingUpdateInProgress", "one or more nodes are rolling out an update"
+	}
+
+	return v1alpha1.ElasticsearchCondition{Type: v1alpha1.Upgrading, Status: status, Reason: reason, Message: message}
+}
+
+func degradedCondition(dpl *v1alpha1.Elasticsearch) v1alpha1.ElasticsearchCondition {
+	status := v1.ConditionFalse
+	reason, message := "ClusterOK", "cluster health is OK and no nodes have failed"
+	if dpl.Status.ClusterHealth == "red" || countPodState(dpl, v1alpha1.PodStateTypeFailed) > 0 {
+		status = v1.ConditionTrue
+		reason, message = "ClusterDegraded", "cluster health is red or a node has failed"
+	}
+
+	return v1alpha1.ElasticsearchCondition{Type: v1alpha1.Degraded, Status: status, Reason: reason, Message: message}
+}
+
+func quorumCondition(dpl *v1alpha1.Elasticsearch) v1alpha1.ElasticsearchCondition {
+	masters := dpl.Status.Pods[v1alpha1.ElasticsearchRoleMaster]
+	ready := len(masters[v1alpha1.PodStateTypeReady])
+	total := ready + len(masters[v1alpha1.PodStateTypeNotReady]) + len(masters[v1alpha1.PodStateTypeUpdating]) + len(masters[v1alpha1.PodStateTypeFailed])
+
+	status := v1.ConditionFalse
+	reason, message := "QuorumOK", "a quorum of master nodes is available"
+	if total > 0 && ready*2 <= total {
+		status = v1.ConditionTrue
+		reason, message = "QuorumLost", fmt.Sprintf("only %v of %v master nodes are ready", ready, total)
+	}
+
+	return v1alpha1.ElasticsearchCondition{Type: v1alpha1.QuorumLost, Status: status, Reason: reason, Message: message}
+}
+
+func countPodState(dpl *v1alpha1.Elasticsearch, state v1alpha1.PodStateType) int {
+	count := 0
+	for _, roleMap := range dpl.Status.Pods {
+		count += len(roleMap[state])
+	}
+	return count
+}
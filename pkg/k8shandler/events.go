@@ -0,0 +1,86 @@
+package k8shandler
+
+import (
+	"k8s.io/api/core/v1"
+
+	v1alpha1 "github.com/openshift/elasticsearch-operator/pkg/apis/elasticsearch/v1alpha1"
+)
+
+const (
+	reasonClusterHealthy  = "ClusterHealthy"
+	reasonClusterDegraded = "ClusterDegraded"
+	reasonNodeNotReady    = "NodeNotReady"
+	reasonNodeUpdating    = "NodeUpdating"
+	reasonNodeRoleLost    = "NodeRoleLost"
+)
+
+// recordTransitions compares the cluster health and pod state observed by
+// this UpdateStatus call against what was previously recorded on dpl and
+// emits Events for anything an operator would want to see in `kubectl
+// describe elasticsearch` without diffing the CR by hand.
+func (cState *ClusterState) recordTransitions(dpl *v1alpha1.Elasticsearch, prevHealth string, prevPods map[v1alpha1.ElasticsearchNodeRole]v1alpha1.PodStateMap) {
+	if cState.Recorder == nil {
+		return
+	}
+
+	cState.recordHealthTransition(dpl, prevHealth, dpl.Status.ClusterHealth)
+	cState.recordPodTransitions(dpl, prevPods, dpl.Status.Pods)
+}
+
+func (cState *ClusterState) recordHealthTransition(dpl *v1alpha1.Elasticsearch, prev, current string) {
+	if prev == current {
+		return
+	}
+
+	switch current {
+	case "green":
+		cState.Recorder.Eventf(dpl, v1.EventTypeNormal, reasonClusterHealthy, "cluster health is now green")
+	case "yellow", "red":
+		cState.Recorder.Eventf(dpl, v1.EventTypeWarning, reasonClusterDegraded, "cluster health went from %v to %v", prev, current)
+	}
+}
+
+func (cState *ClusterState) recordPodTransitions(dpl *v1alpha1.Elasticsearch, prev, current map[v1alpha1.ElasticsearchNodeRole]v1alpha1.PodStateMap) {
+	for role, currentPods := range current {
+		prevPods, hadRole := prev[role]
+		if !hadRole {
+			continue
+		}
+
+		for _, name := range prevPods[v1alpha1.PodStateTypeReady] {
+			if contains(currentPods[v1alpha1.PodStateTypeReady], name) {
+				continue
+			}
+
+			switch {
+			case contains(currentPods[v1alpha1.PodStateTypeUpdating], name):
+				cState.Recorder.Eventf(dpl, v1.EventTypeNormal, reasonNodeUpdating, "pod %v (%v) is rolling out an update", name, role)
+			case contains(currentPods[v1alpha1.PodStateTypeNotReady], name), contains(currentPods[v1alpha1.PodStateTypeFailed], name):
+				cState.Recorder.Eventf(dpl, v1.EventTypeWarning, reasonNodeNotReady, "pod %v (%v) is no longer ready", name, role)
+			}
+		}
+	}
+
+	for role, prevPods := range prev {
+		if podCount(prevPods) > 0 && podCount(current[role]) == 0 {
+			cState.Recorder.Eventf(dpl, v1.EventTypeWarning, reasonNodeRoleLost, "no pods remain for node role %v", role)
+		}
+	}
+}
+
+func podCount(pods v1alpha1.PodStateMap) int {
+	count := 0
+	for _, names := range pods {
+		count += len(names)
+	}
+	return count
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
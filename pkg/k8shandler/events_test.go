@@ -0,0 +1,126 @@
+package k8shandler
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/record"
+
+	v1alpha1 "github.com/openshift/elasticsearch-operator/pkg/apis/elasticsearch/v1alpha1"
+)
+
+// drainEvents reads every event already queued on rec without blocking.
+func drainEvents(rec *record.FakeRecorder) []string {
+	close(rec.Events)
+	var events []string
+	for e := range rec.Events {
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestRecordHealthTransition(t *testing.T) {
+	tests := []struct {
+		name       string
+		prev       string
+		current    string
+		wantReason string
+	}{
+		{name: "green to yellow warns", prev: "green", current: "yellow", wantReason: reasonClusterDegraded},
+		{name: "yellow to red warns", prev: "yellow", current: "red", wantReason: reasonClusterDegraded},
+		{name: "red to green informs", prev: "red", current: "green", wantReason: reasonClusterHealthy},
+		{name: "unchanged emits nothing", prev: "green", current: "green", wantReason: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := record.NewFakeRecorder(10)
+			cState := &ClusterState{Recorder: rec}
+
+			cState.recordHealthTransition(&v1alpha1.Elasticsearch{}, tt.prev, tt.current)
+
+			events := drainEvents(rec)
+			if tt.wantReason == "" {
+				if len(events) != 0 {
+					t.Fatalf("expected no events, got %v", events)
+				}
+				return
+			}
+			if len(events) != 1 || !strings.Contains(events[0], tt.wantReason) {
+				t.Fatalf("expected one event containing %q, got %v", tt.wantReason, events)
+			}
+		})
+	}
+}
+
+func TestRecordPodTransitions(t *testing.T) {
+	prev := map[v1alpha1.ElasticsearchNodeRole]v1alpha1.PodStateMap{
+		v1alpha1.ElasticsearchRoleData: {
+			v1alpha1.PodStateTypeReady: []string{"updating-pod", "failing-pod", "still-ready-pod"},
+		},
+		v1alpha1.ElasticsearchRoleMaster: {
+			v1alpha1.PodStateTypeReady: []string{"lone-master"},
+		},
+	}
+
+	t.Run("pod rolling an update gets a Normal event, not NodeNotReady", func(t *testing.T) {
+		rec := record.NewFakeRecorder(10)
+		cState := &ClusterState{Recorder: rec}
+
+		current := map[v1alpha1.ElasticsearchNodeRole]v1alpha1.PodStateMap{
+			v1alpha1.ElasticsearchRoleData: {
+				v1alpha1.PodStateTypeReady:    []string{"still-ready-pod"},
+				v1alpha1.PodStateTypeUpdating: []string{"updating-pod"},
+				v1alpha1.PodStateTypeNotReady: []string{"failing-pod"},
+			},
+			v1alpha1.ElasticsearchRoleMaster: {
+				v1alpha1.PodStateTypeReady: []string{"lone-master"},
+			},
+		}
+
+		cState.recordPodTransitions(&v1alpha1.Elasticsearch{}, prev, current)
+
+		events := drainEvents(rec)
+		var sawUpdating, sawNotReady bool
+		for _, e := range events {
+			switch {
+			case strings.Contains(e, "updating-pod"):
+				if !strings.Contains(e, reasonNodeUpdating) || !strings.HasPrefix(e, "Normal") {
+					t.Errorf("expected updating-pod event to be a Normal %v event, got %q", reasonNodeUpdating, e)
+				}
+				sawUpdating = true
+			case strings.Contains(e, "failing-pod"):
+				if !strings.Contains(e, reasonNodeNotReady) || !strings.HasPrefix(e, "Warning") {
+					t.Errorf("expected failing-pod event to be a Warning %v event, got %q", reasonNodeNotReady, e)
+				}
+				sawNotReady = true
+			default:
+				t.Errorf("unexpected event: %q", e)
+			}
+		}
+		if !sawUpdating {
+			t.Error("expected an event for updating-pod")
+		}
+		if !sawNotReady {
+			t.Error("expected an event for failing-pod")
+		}
+	})
+
+	t.Run("role disappearing fires NodeRoleLost", func(t *testing.T) {
+		rec := record.NewFakeRecorder(10)
+		cState := &ClusterState{Recorder: rec}
+
+		current := map[v1alpha1.ElasticsearchNodeRole]v1alpha1.PodStateMap{
+			v1alpha1.ElasticsearchRoleData: {
+				v1alpha1.PodStateTypeReady: []string{"updating-pod", "failing-pod", "still-ready-pod"},
+			},
+		}
+
+		cState.recordPodTransitions(&v1alpha1.Elasticsearch{}, prev, current)
+
+		events := drainEvents(rec)
+		if len(events) != 1 || !strings.Contains(events[0], reasonNodeRoleLost) {
+			t.Fatalf("expected a single %v event, got %v", reasonNodeRoleLost, events)
+		}
+	})
+}
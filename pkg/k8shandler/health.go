@@ -0,0 +1,64 @@
+package k8shandler
+
+import (
+	"github.com/sirupsen/logrus"
+
+	v1alpha1 "github.com/openshift/elasticsearch-operator/pkg/apis/elasticsearch/v1alpha1"
+	"github.com/openshift/elasticsearch-operator/pkg/elasticsearch"
+)
+
+// healthClient is the subset of *elasticsearch.Client that
+// populateClusterHealth needs, extracted so the cluster-health path can be
+// exercised in unit tests without mounted certs or a live cluster.
+//
+// Cluster health is read over HTTP through elasticsearch.Client (see
+// pkg/elasticsearch); no pod exec or port-forward backend is involved.
+type healthClient interface {
+	ClusterHealth() (*elasticsearch.ClusterHealth, error)
+}
+
+// defaultHealthClientFactory builds the real Elasticsearch client for a
+// cluster.
+func defaultHealthClientFactory(clusterName, namespace string) (healthClient, error) {
+	return elasticsearch.NewClient(clusterName, namespace)
+}
+
+// healthClientFactory returns cState.HealthClientFactory, falling back to
+// the real Elasticsearch client when none was configured.
+func (cState *ClusterState) healthClientFactory() func(string, string) (healthClient, error) {
+	if cState.HealthClientFactory != nil {
+		return cState.HealthClientFactory
+	}
+	return defaultHealthClientFactory
+}
+
+// populateClusterHealth queries the Elasticsearch cluster directly over
+// HTTP for its health and fills in dpl.Status from the response. It falls
+// back to healthUnknown when the client cannot be built or the request
+// fails: the cluster is unreachable, answers with a non-200 status, or
+// sends a body that does not decode. Unknown fields in a response are
+// ignored and missing ones are left at zero.
+func (cState *ClusterState) populateClusterHealth(dpl *v1alpha1.Elasticsearch) {
+	client, err := cState.healthClientFactory()(dpl.Name, dpl.Namespace)
+	if err != nil {
+		logrus.Debugf("could not build elasticsearch client for %v: %v", dpl.Name, err)
+		dpl.Status.ClusterHealth = healthUnknown
+		return
+	}
+
+	health, err := client.ClusterHealth()
+	if err != nil {
+		logrus.Debugf("could not query cluster health for %v: %v", dpl.Name, err)
+		dpl.Status.ClusterHealth = healthUnknown
+		return
+	}
+
+	dpl.Status.ClusterHealth = health.Status
+	dpl.Status.NumberOfNodes = health.NumberOfNodes
+	dpl.Status.ActiveShards = health.ActiveShards
+	dpl.Status.RelocatingShards = health.RelocatingShards
+	dpl.Status.InitializingShards = health.InitializingShards
+	dpl.Status.UnassignedShards = health.UnassignedShards
+	dpl.Status.PendingTasks = health.NumberOfPendingTasks
+	dpl.Status.ActiveShardsPercent = health.ActiveShardsPercentAsNumber
+}
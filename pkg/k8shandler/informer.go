@@ -0,0 +1,80 @@
+package k8shandler
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const indexByComponent = "component"
+
+// podIndexer is a shared, watch-backed index of Elasticsearch pods across
+// all namespaces, keyed by the same `component=<clusterName>` label
+// rolePodStateMap used to query the apiserver with directly. It is
+// populated once per operator process instead of once per reconcile.
+var podIndexer cache.Indexer
+
+// InitPodIndexer starts the shared pod informer against clientset and
+// blocks until its cache has synced. It must be called once, before any
+// reconciler calls rolePodStateMap.
+func InitPodIndexer(clientset kubernetes.Interface, stopCh <-chan struct{}) error {
+	lw := cache.NewFilteredListWatchFromClient(clientset.CoreV1().RESTClient(), "pods", metav1.NamespaceAll, func(options *metav1.ListOptions) {
+		options.LabelSelector = "component"
+		options.FieldSelector = fields.Everything().String()
+	})
+
+	informer := cache.NewSharedIndexInformer(lw, &v1.Pod{}, 30*time.Minute, cache.Indexers{
+		indexByComponent: componentIndexFunc,
+	})
+
+	podIndexer = informer.GetIndexer()
+
+	go informer.Run(stopCh)
+	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
+		return fmt.Errorf("timed out waiting for elasticsearch pod informer to sync")
+	}
+	return nil
+}
+
+func componentIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		return nil, nil
+	}
+	if component, ok := pod.Labels["component"]; ok {
+		return []string{component}, nil
+	}
+	return nil, nil
+}
+
+// listIndexedPods returns the cached pods in namespace belonging to
+// clusterName that also carry roleLabel=true. roleLabel may be empty to
+// return every pod for the cluster.
+func listIndexedPods(namespace, clusterName, roleLabel string) ([]v1.Pod, error) {
+	if podIndexer == nil {
+		return nil, fmt.Errorf("pod indexer is not initialized: InitPodIndexer must be called before reconciling")
+	}
+
+	items, err := podIndexer.ByIndex(indexByComponent, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]v1.Pod, 0, len(items))
+	for _, obj := range items {
+		pod := obj.(*v1.Pod)
+		if pod.Namespace != namespace {
+			continue
+		}
+		if roleLabel != "" && pod.Labels[roleLabel] != "true" {
+			continue
+		}
+		pods = append(pods, *pod)
+	}
+	return pods, nil
+}
@@ -0,0 +1,97 @@
+// Package readiness classifies an Elasticsearch node's health by looking
+// past the Pod to the workload that owns it, the way Helm 3.5's
+// resource-status-check (and the ONAP k8splugin statuscheck package) judge
+// a Deployment or StatefulSet rollout rather than trusting container
+// readiness alone.
+package readiness
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// State is the aggregated readiness of the workload backing a single
+// Elasticsearch node.
+type State int
+
+const (
+	// Ready means the pod and its owning workload have both finished
+	// rolling out.
+	Ready State = iota
+	// Updating means the pod itself is healthy but its owning workload
+	// has not yet finished rolling out, e.g. a rolling restart is
+	// in-progress.
+	Updating
+	// NotReady means the pod itself is not ready.
+	NotReady
+	// Failed means the pod has failed outright.
+	Failed
+)
+
+// Owner is the workload backing an Elasticsearch node, as recorded on
+// nodeState.Actual. At most one of Deployment/StatefulSet is set.
+type Owner struct {
+	Deployment  *appsv1.Deployment
+	StatefulSet *appsv1.StatefulSet
+}
+
+// PodState classifies pod and its owning workload into a single State.
+func PodState(pod *v1.Pod, owner Owner) State {
+	if pod == nil || pod.Status.Phase == v1.PodFailed {
+		return Failed
+	}
+
+	if !podReady(pod) {
+		return NotReady
+	}
+
+	if !ownerReady(owner) {
+		return Updating
+	}
+
+	return Ready
+}
+
+// podReady checks the kubelet's own PodReady condition, rather than every
+// containerStatus.Ready, since a pod can pass all container readiness
+// checks and still be failing a readiness gate.
+func podReady(pod *v1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.PodReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func ownerReady(owner Owner) bool {
+	switch {
+	case owner.Deployment != nil:
+		return deploymentReady(owner.Deployment)
+	case owner.StatefulSet != nil:
+		return statefulSetReady(owner.StatefulSet)
+	default:
+		return true
+	}
+}
+
+func deploymentReady(d *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+
+	return d.Status.UpdatedReplicas == replicas &&
+		d.Status.AvailableReplicas == replicas &&
+		d.Status.ObservedGeneration >= d.Generation
+}
+
+func statefulSetReady(s *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+
+	return s.Status.ReadyReplicas == replicas &&
+		s.Status.CurrentRevision == s.Status.UpdateRevision
+}
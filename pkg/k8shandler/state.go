@@ -0,0 +1,44 @@
+package k8shandler
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	v1alpha1 "github.com/openshift/elasticsearch-operator/pkg/apis/elasticsearch/v1alpha1"
+)
+
+// ClusterState tracks the reconciler's in-memory view of the nodes backing
+// an Elasticsearch cluster, built up during reconciliation and consumed by
+// UpdateStatus.
+type ClusterState struct {
+	Nodes []*nodeState
+
+	// Recorder emits Events on the Elasticsearch CR for transitions
+	// UpdateStatus observes. May be nil, in which case no Events are
+	// recorded.
+	Recorder record.EventRecorder
+
+	// HealthClientFactory builds the client populateClusterHealth queries
+	// for cluster health. Defaults to the real Elasticsearch client when
+	// nil; tests set this to return a fake.
+	HealthClientFactory func(clusterName, namespace string) (healthClient, error)
+}
+
+// nodeState pairs the desired configuration for an Elasticsearch node with
+// the workload actually observed on the cluster for it.
+type nodeState struct {
+	Desired desiredNodeState
+	Actual  actualNodeState
+}
+
+type desiredNodeState struct {
+	Roles []v1alpha1.ElasticsearchNodeRole
+}
+
+type actualNodeState struct {
+	Deployment  *appsv1.Deployment
+	ReplicaSet  *appsv1.ReplicaSet
+	StatefulSet *appsv1.StatefulSet
+	Pod         *v1.Pod
+}
@@ -1,12 +1,12 @@
 package k8shandler
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"k8s.io/client-go/util/retry"
 
 	v1alpha1 "github.com/openshift/elasticsearch-operator/pkg/apis/elasticsearch/v1alpha1"
+	"github.com/openshift/elasticsearch-operator/pkg/k8shandler/readiness"
 	"github.com/operator-framework/operator-sdk/pkg/sdk"
 	"github.com/sirupsen/logrus"
 	"k8s.io/api/core/v1"
@@ -16,6 +16,9 @@ const healthUnknown = "cluster health unknown"
 
 // UpdateStatus updates the status of Elasticsearch CRD
 func (cState *ClusterState) UpdateStatus(dpl *v1alpha1.Elasticsearch) error {
+	prevHealth := dpl.Status.ClusterHealth
+	prevPods := dpl.Status.Pods
+
 	nretries := -1
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		nretries++
@@ -23,13 +26,14 @@ func (cState *ClusterState) UpdateStatus(dpl *v1alpha1.Elasticsearch) error {
 			logrus.Debugf("Could not get Elasticsearch %v: %v", dpl.Name, getErr)
 			return getErr
 		}
-		dpl.Status.ClusterHealth = clusterHealth(dpl)
+		cState.populateClusterHealth(dpl)
 		dpl.Status.Nodes = []v1alpha1.ElasticsearchNodeStatus{}
 		for _, node := range cState.Nodes {
 			updateNodeStatus(node, &dpl.Status)
 		}
 
-		dpl.Status.Pods = rolePodStateMap(dpl.Namespace, dpl.Name)
+		dpl.Status.Pods = cState.rolePodStateMap(dpl.Namespace, dpl.Name)
+		computeConditions(dpl)
 		if updateErr := sdk.Update(dpl); updateErr != nil {
 			logrus.Debugf("Failed to update Elasticsearch %v status: %v", dpl.Name, updateErr)
 			return updateErr
@@ -41,6 +45,8 @@ func (cState *ClusterState) UpdateStatus(dpl *v1alpha1.Elasticsearch) error {
 		return fmt.Errorf("Error: could not update status for Elasticsearch %v after %v retries: %v", dpl.Name, nretries, retryErr)
 	}
 	logrus.Debugf("Updated Elasticsearch %v after %v retries", dpl.Name, nretries)
+
+	cState.recordTransitions(dpl, prevHealth, prevPods)
 	return nil
 }
 
@@ -70,106 +76,58 @@ func updateNodeStatus(node *nodeState, dpl *v1alpha1.ElasticsearchStatus) {
 	dpl.Nodes = append(dpl.Nodes, nodeStatus)
 }
 
-func clusterHealth(dpl *v1alpha1.Elasticsearch) string {
-	pods, err := listRunningPods(dpl.Name, dpl.Namespace)
-	if err != nil {
-		return healthUnknown
-	}
+func (cState *ClusterState) rolePodStateMap(namespace string, clusterName string) map[v1alpha1.ElasticsearchNodeRole]v1alpha1.PodStateMap {
 
-	// no running elasticsearch pods were found
-	if len(pods.Items) == 0 {
-		return ""
-	}
+	clientPods, _ := listIndexedPods(namespace, clusterName, "es-node-client")
+	dataPods, _ := listIndexedPods(namespace, clusterName, "es-node-data")
+	masterPods, _ := listIndexedPods(namespace, clusterName, "es-node-master")
 
-	// use arbitrary pod
-	pod := pods.Items[0]
-	// when running in a pod, use the values provided for the sa
-	// this is primarily used when testing
-	kubeConfigPath := lookupEnvWithDefault("KUBERNETES_CONFIG", "")
-	masterURL := "https://kubernetes.default.svc"
-	if kubeConfigPath == "" {
-		// ExecConfig requires both are "", or both have a real value
-		masterURL = ""
-	}
-
-	config := &ExecConfig{
-		pod:            &pod,
-		containerName:  "elasticsearch",
-		command:        []string{"es_util", "--query=_cluster/health?pretty=true"},
-		kubeConfigPath: kubeConfigPath,
-		masterURL:      masterURL,
-		stdOut:         true,
-		stdErr:         true,
-		tty:            false,
-	}
+	owners := cState.podOwners()
 
-	execOut, _, err := PodExec(config)
-	if err != nil {
-		logrus.Debug(err)
-		return healthUnknown
-	}
-
-	var result map[string]interface{}
-
-	err = json.Unmarshal(execOut.Bytes(), &result)
-	if err != nil {
-		logrus.Debug("could not unmarshal: %v", err)
-		return healthUnknown
-	}
-	if _, present := result["status"]; !present {
-		logrus.Debug("response from elasticsearch health API did not contain 'status' field")
-		return healthUnknown
+	return map[v1alpha1.ElasticsearchNodeRole]v1alpha1.PodStateMap{
+		v1alpha1.ElasticsearchRoleClient: podStateMap(clientPods, owners),
+		v1alpha1.ElasticsearchRoleData:   podStateMap(dataPods, owners),
+		v1alpha1.ElasticsearchRoleMaster: podStateMap(masterPods, owners),
 	}
-
-	return result["status"].(string)
 }
 
-func rolePodStateMap(namespace string, clusterName string) map[v1alpha1.ElasticsearchNodeRole]v1alpha1.PodStateMap {
-
-	baseSelector := fmt.Sprintf("component=%s", clusterName)
-	clientList, _ := GetPodList(namespace, fmt.Sprintf("%s,%s", baseSelector, "es-node-client=true"))
-	dataList, _ := GetPodList(namespace, fmt.Sprintf("%s,%s", baseSelector, "es-node-data=true"))
-	masterList, _ := GetPodList(namespace, fmt.Sprintf("%s,%s", baseSelector, "es-node-master=true"))
-
-	return map[v1alpha1.ElasticsearchNodeRole]v1alpha1.PodStateMap{
-		v1alpha1.ElasticsearchRoleClient: podStateMap(clientList.Items),
-		v1alpha1.ElasticsearchRoleData:   podStateMap(dataList.Items),
-		v1alpha1.ElasticsearchRoleMaster: podStateMap(masterList.Items),
+// podOwners indexes the owning Deployment/StatefulSet for each pod this
+// ClusterState knows about, by pod name.
+func (cState *ClusterState) podOwners() map[string]readiness.Owner {
+	owners := map[string]readiness.Owner{}
+	for _, node := range cState.Nodes {
+		if node.Actual.Pod == nil {
+			continue
+		}
+		owners[node.Actual.Pod.Name] = readiness.Owner{
+			Deployment:  node.Actual.Deployment,
+			StatefulSet: node.Actual.StatefulSet,
+		}
 	}
+	return owners
 }
 
-func podStateMap(podList []v1.Pod) v1alpha1.PodStateMap {
+func podStateMap(podList []v1.Pod, owners map[string]readiness.Owner) v1alpha1.PodStateMap {
 	stateMap := map[v1alpha1.PodStateType][]string{
 		v1alpha1.PodStateTypeReady:    []string{},
 		v1alpha1.PodStateTypeNotReady: []string{},
+		v1alpha1.PodStateTypeUpdating: []string{},
 		v1alpha1.PodStateTypeFailed:   []string{},
 	}
 
-	for _, pod := range podList {
-		switch pod.Status.Phase {
-		case v1.PodPending:
-			stateMap[v1alpha1.PodStateTypeNotReady] = append(stateMap[v1alpha1.PodStateTypeNotReady], pod.Name)
-		case v1.PodRunning:
-			if isPodReady(pod) {
-				stateMap[v1alpha1.PodStateTypeReady] = append(stateMap[v1alpha1.PodStateTypeReady], pod.Name)
-			} else {
-				stateMap[v1alpha1.PodStateTypeNotReady] = append(stateMap[v1alpha1.PodStateTypeNotReady], pod.Name)
-			}
-		case v1.PodFailed:
+	for i := range podList {
+		pod := &podList[i]
+		switch readiness.PodState(pod, owners[pod.Name]) {
+		case readiness.Ready:
+			stateMap[v1alpha1.PodStateTypeReady] = append(stateMap[v1alpha1.PodStateTypeReady], pod.Name)
+		case readiness.Updating:
+			stateMap[v1alpha1.PodStateTypeUpdating] = append(stateMap[v1alpha1.PodStateTypeUpdating], pod.Name)
+		case readiness.Failed:
 			stateMap[v1alpha1.PodStateTypeFailed] = append(stateMap[v1alpha1.PodStateTypeFailed], pod.Name)
+		default:
+			stateMap[v1alpha1.PodStateTypeNotReady] = append(stateMap[v1alpha1.PodStateTypeNotReady], pod.Name)
 		}
 	}
 
 	return stateMap
 }
-
-func isPodReady(pod v1.Pod) bool {
-
-	for _, container := range pod.Status.ContainerStatuses {
-		if !container.Ready {
-			return false
-		}
-	}
-
-	return true
-}
@@ -0,0 +1,187 @@
+package k8shandler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/openshift/elasticsearch-operator/pkg/apis/elasticsearch/v1alpha1"
+	"github.com/openshift/elasticsearch-operator/pkg/elasticsearch"
+	"github.com/openshift/elasticsearch-operator/pkg/k8shandler/readiness"
+)
+
+// fakeHealthClient satisfies healthClient with a canned response, so
+// populateClusterHealth can be driven in unit tests without mounted certs
+// or a live cluster.
+type fakeHealthClient struct {
+	health *elasticsearch.ClusterHealth
+	err    error
+}
+
+func (f *fakeHealthClient) ClusterHealth() (*elasticsearch.ClusterHealth, error) {
+	return f.health, f.err
+}
+
+func TestPopulateClusterHealthPopulatesStatusFromClient(t *testing.T) {
+	cState := &ClusterState{
+		HealthClientFactory: func(clusterName, namespace string) (healthClient, error) {
+			return &fakeHealthClient{health: &elasticsearch.ClusterHealth{
+				Status:                      "green",
+				NumberOfNodes:               3,
+				ActiveShards:                10,
+				RelocatingShards:            1,
+				InitializingShards:          2,
+				UnassignedShards:            0,
+				NumberOfPendingTasks:        0,
+				ActiveShardsPercentAsNumber: 100,
+			}}, nil
+		},
+	}
+
+	dpl := &v1alpha1.Elasticsearch{}
+	cState.populateClusterHealth(dpl)
+
+	want := v1alpha1.ElasticsearchStatus{
+		ClusterHealth:       "green",
+		NumberOfNodes:       3,
+		ActiveShards:        10,
+		RelocatingShards:    1,
+		InitializingShards:  2,
+		PendingTasks:        0,
+		ActiveShardsPercent: 100,
+	}
+	if !reflect.DeepEqual(dpl.Status, want) {
+		t.Errorf("populateClusterHealth() status = %+v, want %+v", dpl.Status, want)
+	}
+}
+
+func TestPopulateClusterHealthFallsBackToUnknownOnError(t *testing.T) {
+	cState := &ClusterState{
+		HealthClientFactory: func(clusterName, namespace string) (healthClient, error) {
+			return &fakeHealthClient{err: errors.New("connection refused")}, nil
+		},
+	}
+
+	dpl := &v1alpha1.Elasticsearch{}
+	cState.populateClusterHealth(dpl)
+
+	if dpl.Status.ClusterHealth != healthUnknown {
+		t.Errorf("expected ClusterHealth %q on query error, got %q", healthUnknown, dpl.Status.ClusterHealth)
+	}
+}
+
+func TestUpdateNodeStatus(t *testing.T) {
+	node := &nodeState{
+		Desired: desiredNodeState{Roles: []v1alpha1.ElasticsearchNodeRole{v1alpha1.ElasticsearchRoleData}},
+		Actual: actualNodeState{
+			Deployment: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "es-data-1"}},
+			Pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "es-data-1-abc"},
+				Status:     v1.PodStatus{Phase: v1.PodRunning},
+			},
+		},
+	}
+
+	status := &v1alpha1.ElasticsearchStatus{}
+	updateNodeStatus(node, status)
+
+	if len(status.Nodes) != 1 {
+		t.Fatalf("expected exactly one node status, got %v", len(status.Nodes))
+	}
+
+	got := status.Nodes[0]
+	want := v1alpha1.ElasticsearchNodeStatus{
+		DeploymentName: "es-data-1",
+		PodName:        "es-data-1-abc",
+		Status:         string(v1.PodRunning),
+		Roles:          []v1alpha1.ElasticsearchNodeRole{v1alpha1.ElasticsearchRoleData},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("updateNodeStatus() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPodStateMapBucketsByReadinessAndOwner(t *testing.T) {
+	readyCondition := v1.PodCondition{Type: v1.PodReady, Status: v1.ConditionTrue}
+
+	readyReplicas := int32(1)
+	rolledOutDeployment := &appsv1.Deployment{
+		Spec:   appsv1.DeploymentSpec{Replicas: &readyReplicas},
+		Status: appsv1.DeploymentStatus{UpdatedReplicas: 1, AvailableReplicas: 1, ObservedGeneration: 1},
+	}
+	updatingDeployment := &appsv1.Deployment{
+		Spec:   appsv1.DeploymentSpec{Replicas: &readyReplicas},
+		Status: appsv1.DeploymentStatus{UpdatedReplicas: 0, AvailableReplicas: 1, ObservedGeneration: 1},
+	}
+
+	pods := []v1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready"},
+			Status:     v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{readyCondition}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "updating"},
+			Status:     v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{readyCondition}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "notready"},
+			Status:     v1.PodStatus{Phase: v1.PodPending},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "failed"},
+			Status:     v1.PodStatus{Phase: v1.PodFailed},
+		},
+	}
+
+	owners := map[string]readiness.Owner{
+		"ready":    {Deployment: rolledOutDeployment},
+		"updating": {Deployment: updatingDeployment},
+	}
+
+	result := podStateMap(pods, owners)
+
+	assertPodNames(t, result[v1alpha1.PodStateTypeReady], "ready")
+	assertPodNames(t, result[v1alpha1.PodStateTypeUpdating], "updating")
+	assertPodNames(t, result[v1alpha1.PodStateTypeNotReady], "notready")
+	assertPodNames(t, result[v1alpha1.PodStateTypeFailed], "failed")
+}
+
+func assertPodNames(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got pod names %v, want %v", got, want)
+	}
+}
+
+func TestMergeConditionsPreservesTransitionTimeUntilStatusFlips(t *testing.T) {
+	firstReconcile := metav1.NewTime(metav1.Now().Add(-time.Minute))
+	existing := []v1alpha1.ElasticsearchCondition{
+		{Type: v1alpha1.ClusterHealthy, Status: v1.ConditionTrue, LastTransitionTime: firstReconcile},
+	}
+
+	now := metav1.Now()
+
+	t.Run("status unchanged keeps original transition time", func(t *testing.T) {
+		desired := []v1alpha1.ElasticsearchCondition{{Type: v1alpha1.ClusterHealthy, Status: v1.ConditionTrue}}
+		merged := mergeConditions(existing, desired, now)
+		if !merged[0].LastTransitionTime.Equal(&firstReconcile) {
+			t.Errorf("expected LastTransitionTime to be preserved, got %v", merged[0].LastTransitionTime)
+		}
+		if !merged[0].LastHeartbeatTime.Equal(&now) {
+			t.Errorf("expected LastHeartbeatTime to be bumped to %v, got %v", now, merged[0].LastHeartbeatTime)
+		}
+	})
+
+	t.Run("status flip bumps transition time", func(t *testing.T) {
+		desired := []v1alpha1.ElasticsearchCondition{{Type: v1alpha1.ClusterHealthy, Status: v1.ConditionFalse}}
+		merged := mergeConditions(existing, desired, now)
+		if !merged[0].LastTransitionTime.Equal(&now) {
+			t.Errorf("expected LastTransitionTime to be bumped on status flip, got %v", merged[0].LastTransitionTime)
+		}
+	})
+}